@@ -0,0 +1,156 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// auditors lists every RepoAuditor wired into the `all` subcommand.
+var auditors = []RepoAuditor{
+	codeScanningAuditor{},
+	secretScanningAuditor{},
+	dependabotAuditor{},
+}
+
+// auditAllCmd runs every GHAS feature auditor against the requested
+// organizations or repository, writing one report per feature in
+// --output-format.
+var auditAllCmd = &cobra.Command{
+	Use:   "all",
+	Short: "Audit every GHAS feature (code scanning, secret scanning, Dependabot)",
+	Long:  `Audit every GHAS feature (code scanning, secret scanning, Dependabot), writing one report file per feature in the selected output format.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runAll(cmd.Context())
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(auditAllCmd)
+}
+
+// runAll resolves the repositories to audit and runs every auditor against them.
+func runAll(ctx context.Context) error {
+	client, err := NewClient()
+	if err != nil {
+		return err
+	}
+
+	targets, err := resolveTargets(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	scanner := NewScanner(client, Concurrency)
+	for _, auditor := range auditors {
+		path := auditorOutputPath(auditor, OutputFormat)
+		if err := auditAll(ctx, scanner, auditor, targets, path); err != nil {
+			return fmt.Errorf("%s audit: %w", auditor.Name(), err)
+		}
+	}
+	return nil
+}
+
+// resolveTargets expands --repository/--organizations into the set of
+// repositories to audit, keyed by organization.
+func resolveTargets(ctx context.Context, client *Client) (map[string][]string, error) {
+	if Repository != "" {
+		org, repo := ParseRepository(Repository)
+		if org == "" || repo == "" {
+			return nil, fmt.Errorf("invalid --repository %q, expected owner/repo", Repository)
+		}
+		return map[string][]string{org: {repo}}, nil
+	}
+
+	targets := make(map[string][]string)
+	for _, org := range strings.Split(Organizations, ",") {
+		org = strings.TrimSpace(org)
+		if org == "" {
+			continue
+		}
+		repos, err := ListRepos(ctx, client, org)
+		if err != nil {
+			return nil, fmt.Errorf("listing repos for %s: %w", org, err)
+		}
+		targets[org] = repos
+	}
+	return targets, nil
+}
+
+// auditAll runs a single auditor across every org/repo in targets, writing
+// the results to outputPath in --output-format.
+func auditAll(ctx context.Context, scanner *Scanner, auditor RepoAuditor, targets map[string][]string, outputPath string) error {
+	reporter, err := NewReporter(OutputFormat, outputPath, auditor.CSVHeader())
+	if err != nil {
+		return err
+	}
+
+	var failed int
+	for org, repos := range targets {
+		results := scanner.Scan(ctx, org, repos, func(ctx context.Context, client *Client, org string, repo string) ([]string, map[string]any, error) {
+			row, fields, err := auditor.Audit(ctx, client, org, repo)
+			return []string(row), fields, err
+		})
+		for result := range results {
+			if result.Err != nil {
+				fmt.Fprintf(os.Stderr, "skipping %s/%s (%s): %v\n", result.Org, result.Repo, auditor.Name(), result.Err)
+				failed++
+			}
+			err := reporter.WriteRecord(AuditRecord{
+				Organization: result.Org,
+				Repository:   result.Repo,
+				Header:       auditor.CSVHeader(),
+				Values:       result.Row,
+				Fields:       result.Fields,
+				Err:          result.Err,
+			})
+			if err != nil {
+				reporter.Close()
+				return fmt.Errorf("failed to write result for %s/%s: %w", result.Org, result.Repo, err)
+			}
+		}
+	}
+
+	if failed > 0 {
+		fmt.Fprintf(os.Stderr, "%d repositories failed the %s audit\n", failed, auditor.Name())
+	}
+	return reporter.Close()
+}
+
+// auditorOutputPath derives a per-auditor report path from --csv-output, so
+// the `all` subcommand's auditors don't clobber each other's output,
+// defaulting to "ghas-audit" when it isn't set, with an extension matching format.
+func auditorOutputPath(auditor RepoAuditor, format string) string {
+	base := CSVOutput
+	if base == "" {
+		base = "ghas-audit.csv"
+	}
+	base = strings.TrimSuffix(base, ".csv")
+
+	ext := format
+	if ext == "" {
+		ext = OutputFormatCSV
+	}
+	return fmt.Sprintf("%s-%s.%s", base, auditor.Name(), ext)
+}
+
+// reportOutputPath derives a single-auditor report path from --csv-output,
+// writing to exactly what the user asked for (or the "ghas-audit" default)
+// with an extension matching format, unlike auditorOutputPath's per-auditor
+// suffix.
+func reportOutputPath(format string) string {
+	base := CSVOutput
+	if base == "" {
+		base = "ghas-audit.csv"
+	}
+	base = strings.TrimSuffix(base, ".csv")
+
+	ext := format
+	if ext == "" {
+		ext = OutputFormatCSV
+	}
+	return fmt.Sprintf("%s.%s", base, ext)
+}
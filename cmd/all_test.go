@@ -0,0 +1,33 @@
+package cmd
+
+import "testing"
+
+func TestReportOutputPathWritesExactlyWhatWasAsked(t *testing.T) {
+	old := CSVOutput
+	defer func() { CSVOutput = old }()
+
+	CSVOutput = "report.csv"
+	if got, want := reportOutputPath(OutputFormatCSV), "report.csv"; got != want {
+		t.Errorf("reportOutputPath(csv) = %q, want %q", got, want)
+	}
+	if got, want := reportOutputPath(OutputFormatJSON), "report.json"; got != want {
+		t.Errorf("reportOutputPath(json) = %q, want %q", got, want)
+	}
+
+	CSVOutput = ""
+	if got, want := reportOutputPath(OutputFormatCSV), "ghas-audit.csv"; got != want {
+		t.Errorf("reportOutputPath(csv) with no --csv-output = %q, want %q", got, want)
+	}
+}
+
+func TestAuditorOutputPathAddsPerAuditorSuffix(t *testing.T) {
+	old := CSVOutput
+	defer func() { CSVOutput = old }()
+
+	CSVOutput = "report.csv"
+	got := auditorOutputPath(codeScanningAuditor{}, OutputFormatCSV)
+	want := "report-code-scanning.csv"
+	if got != want {
+		t.Errorf("auditorOutputPath(csv) = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"context"
+	"strings"
+)
+
+// Row is a single CSV row produced by a RepoAuditor.
+type Row []string
+
+// RepoAuditor audits a single GHAS feature across repositories and reports
+// each result as a CSV row alongside the same data as a structured payload.
+// Implementations must be safe to call from multiple goroutines, since a
+// Scanner audits repos concurrently.
+type RepoAuditor interface {
+	// Name identifies the auditor, used to name its output file under `all`.
+	Name() string
+	// CSVHeader returns the column names for this auditor's CSV output.
+	CSVHeader() []string
+	// Audit inspects a single repository and returns its CSV row alongside
+	// a structured payload with the same data in natural types (lists,
+	// numbers, booleans), for the JSON and NDJSON reporters. Both are built
+	// from the same typed result so they can't drift apart.
+	Audit(ctx context.Context, client *Client, org string, repo string) (Row, map[string]any, error)
+}
+
+// codeScanningAuditor reports a repository's code-scanning default-setup
+// configuration and how well it covers the languages GitHub detects.
+type codeScanningAuditor struct{}
+
+// Name identifies this auditor for the `all` subcommand's output file.
+func (codeScanningAuditor) Name() string { return "code-scanning" }
+
+// CSVHeader returns the column names for this auditor's CSV output.
+func (codeScanningAuditor) CSVHeader() []string {
+	return []string{
+		"Organization", "Repository", "State", "QuerySuite", "UpdatedAt",
+		"ConfiguredLanguages", "DetectedLanguages", "UncoveredLanguages",
+	}
+}
+
+// Audit inspects a single repository's code-scanning default setup and
+// flags any detected language it doesn't cover.
+func (codeScanningAuditor) Audit(ctx context.Context, client *Client, org string, repo string) (Row, map[string]any, error) {
+	setup, err := GetDefaultSetup(ctx, client, org, repo)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	langs, err := GetLanguages(ctx, client, org, repo)
+	if err != nil {
+		return nil, nil, err
+	}
+	detected := NormalizeLanguages(langs)
+	uncovered := ArrayDiff(detected, setup.Languages)
+
+	row := Row{
+		org,
+		repo,
+		setup.State,
+		setup.QuerySuite,
+		setup.UpdatedAt,
+		strings.Join(setup.Languages, ";"),
+		strings.Join(detected, ";"),
+		strings.Join(uncovered, ";"),
+	}
+	fields := map[string]any{
+		"state":               setup.State,
+		"querySuite":          setup.QuerySuite,
+		"updatedAt":           setup.UpdatedAt,
+		"configuredLanguages": setup.Languages,
+		"detectedLanguages":   detected,
+		"uncoveredLanguages":  uncovered,
+	}
+	return row, fields, nil
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeTestRSAKey writes a freshly generated RSA private key (PEM encoded)
+// to a temp file and returns its path, matching the format ghinstallation
+// expects from --app-private-key.
+func writeTestRSAKey(t *testing.T) string {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	block := &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}
+	path := filepath.Join(t.TempDir(), "app.pem")
+	if err := os.WriteFile(path, pem.EncodeToMemory(block), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	return path
+}
+
+func TestNewHTTPClientUsesAppInstallationAuthWhenConfigured(t *testing.T) {
+	origID, origKey, origInstall := AppID, AppPrivateKey, AppInstallationID
+	defer func() { AppID, AppPrivateKey, AppInstallationID = origID, origKey, origInstall }()
+
+	AppID = 12345
+	AppInstallationID = 67890
+	AppPrivateKey = writeTestRSAKey(t)
+
+	if _, err := newHTTPClient(); err != nil {
+		t.Fatalf("newHTTPClient with a valid App key = %v, want the App installation transport to build successfully", err)
+	}
+}
+
+func TestNewHTTPClientReportsBadAppKey(t *testing.T) {
+	origID, origKey, origInstall := AppID, AppPrivateKey, AppInstallationID
+	defer func() { AppID, AppPrivateKey, AppInstallationID = origID, origKey, origInstall }()
+
+	AppID = 12345
+	AppInstallationID = 67890
+	AppPrivateKey = filepath.Join(t.TempDir(), "does-not-exist.pem")
+
+	_, err := newHTTPClient()
+	if err == nil || !strings.Contains(err.Error(), "GitHub App installation transport") {
+		t.Fatalf("newHTTPClient with a missing key file = %v, want an error naming the App installation transport", err)
+	}
+}
+
+func TestNewHTTPClientFallsBackWithoutAppConfig(t *testing.T) {
+	origID, origKey, origInstall := AppID, AppPrivateKey, AppInstallationID
+	defer func() { AppID, AppPrivateKey, AppInstallationID = origID, origKey, origInstall }()
+
+	AppID, AppInstallationID, AppPrivateKey = 0, 0, ""
+
+	// With App auth unconfigured, newHTTPClient must not attempt to parse
+	// AppPrivateKey as a key file - any failure here comes from falling
+	// back to api.DefaultHTTPClient(), not from the App installation path.
+	_, err := newHTTPClient()
+	if err != nil && strings.Contains(err.Error(), "GitHub App installation transport") {
+		t.Fatalf("newHTTPClient without App config took the App installation path: %v", err)
+	}
+}
@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+)
+
+// codeScanningAuditCmd audits code-scanning default-setup configuration
+// and language coverage across the requested organizations or repository,
+// parallelized by a Scanner.
+var codeScanningAuditCmd = &cobra.Command{
+	Use:   "code-scanning",
+	Short: "Audit code-scanning default-setup configuration",
+	Long:  `Audit code-scanning default-setup configuration and language coverage across repositories.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runCodeScanningAudit(cmd.Context())
+	},
+}
+
+// runCodeScanningAudit resolves the repositories to audit and runs the
+// code-scanning auditor against them.
+func runCodeScanningAudit(ctx context.Context) error {
+	client, err := NewClient()
+	if err != nil {
+		return err
+	}
+
+	targets, err := resolveTargets(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	scanner := NewScanner(client, Concurrency)
+	return auditAll(ctx, scanner, codeScanningAuditor{}, targets, reportOutputPath(OutputFormat))
+}
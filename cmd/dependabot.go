@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/google/go-github/v57/github"
+)
+
+// dependabotSeverities are the Dependabot alert severities tallied per repository.
+var dependabotSeverities = []string{"critical", "high", "medium", "low"}
+
+// dependabotAuditor reports a repository's Dependabot (vulnerability
+// alerts) enablement alongside its open alert counts by severity.
+type dependabotAuditor struct{}
+
+// Name identifies this auditor for the `all` subcommand's output file.
+func (dependabotAuditor) Name() string { return "dependabot" }
+
+// CSVHeader returns the column names for this auditor's CSV output.
+func (dependabotAuditor) CSVHeader() []string {
+	return []string{"Organization", "Repository", "VulnerabilityAlertsEnabled", "Critical", "High", "Medium", "Low"}
+}
+
+// Audit inspects a single repository's Dependabot enablement and open alerts.
+func (dependabotAuditor) Audit(ctx context.Context, client *Client, org string, repo string) (Row, map[string]any, error) {
+	var enabled bool
+	err := client.withRetry(ctx, func() (*github.Response, error) {
+		var (
+			err  error
+			resp *github.Response
+		)
+		enabled, resp, err = client.rest.Repositories.GetVulnerabilityAlerts(ctx, org, repo)
+		return resp, err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	counts, err := countDependabotAlertsBySeverity(ctx, client, org, repo)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	row := Row{org, repo, strconv.FormatBool(enabled)}
+	fields := map[string]any{"vulnerabilityAlertsEnabled": enabled}
+	for _, severity := range dependabotSeverities {
+		row = append(row, strconv.Itoa(counts[severity]))
+		fields[severity] = counts[severity]
+	}
+	return row, fields, nil
+}
+
+// countDependabotAlertsBySeverity counts open Dependabot alerts for a
+// repository, grouped by severity.
+func countDependabotAlertsBySeverity(ctx context.Context, client *Client, org string, repo string) (map[string]int, error) {
+	opts := &github.ListAlertsOptions{
+		State:       github.String("open"),
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+
+	counts := make(map[string]int, len(dependabotSeverities))
+	for {
+		var (
+			alerts []*github.DependabotAlert
+			resp   *github.Response
+		)
+		err := client.withRetry(ctx, func() (*github.Response, error) {
+			var err error
+			alerts, resp, err = client.rest.Dependabot.ListRepoAlerts(ctx, org, repo, opts)
+			return resp, err
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, alert := range alerts {
+			severity := strings.ToLower(alert.GetSecurityVulnerability().GetSeverity())
+			counts[severity]++
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.ListOptions.Page = resp.NextPage
+	}
+
+	return counts, nil
+}
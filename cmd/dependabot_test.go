@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestCountDependabotAlertsBySeverityPaginates(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/acme/widgets/dependabot/alerts", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			fmt.Fprint(w, `[{"security_vulnerability":{"severity":"low"}}]`)
+			return
+		}
+		w.Header().Set("Link", `<http://`+r.Host+`/repos/acme/widgets/dependabot/alerts?page=2>; rel="next"`)
+		fmt.Fprint(w, `[
+			{"security_vulnerability":{"severity":"critical"}},
+			{"security_vulnerability":{"severity":"Critical"}},
+			{"security_vulnerability":{"severity":"high"}}
+		]`)
+	})
+	client := newTestClient(t, mux)
+
+	counts, err := countDependabotAlertsBySeverity(context.Background(), client, "acme", "widgets")
+	if err != nil {
+		t.Fatalf("countDependabotAlertsBySeverity: %v", err)
+	}
+
+	want := map[string]int{"critical": 2, "high": 1, "low": 1}
+	for severity, wantCount := range want {
+		if counts[severity] != wantCount {
+			t.Errorf("counts[%q] = %d, want %d (got %v)", severity, counts[severity], wantCount, counts)
+		}
+	}
+}
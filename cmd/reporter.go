@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// Supported --output-format values.
+const (
+	OutputFormatCSV    = "csv"
+	OutputFormatJSON   = "json"
+	OutputFormatNDJSON = "ndjson"
+)
+
+// AuditRecord is one repository's result from a single RepoAuditor,
+// normalized so every output format can render it the same way. Values is
+// used by the CSV reporter; Fields carries the same data with its natural
+// types (lists, numbers, booleans) for the JSON and NDJSON reporters.
+type AuditRecord struct {
+	Organization string
+	Repository   string
+	Header       []string
+	Values       []string
+	Fields       map[string]any
+	Err          error
+}
+
+// Reporter renders a stream of AuditRecords in a particular output format.
+// WriteRecord may be called many times before Close.
+type Reporter interface {
+	WriteRecord(record AuditRecord) error
+	Close() error
+}
+
+// NewReporter builds the Reporter for the given --output-format, writing to
+// path. header is used by formats (CSV) that need column names up front.
+func NewReporter(format string, path string, header []string) (Reporter, error) {
+	switch format {
+	case "", OutputFormatCSV:
+		return newCSVReporter(path, header)
+	case OutputFormatJSON:
+		return newJSONReporter(path)
+	case OutputFormatNDJSON:
+		return newNDJSONReporter(path)
+	default:
+		return nil, fmt.Errorf("unknown output format %q", format)
+	}
+}
+
+// jsonRecord is the shape written by both the JSON and NDJSON reporters.
+type jsonRecord struct {
+	Organization string         `json:"organization"`
+	Repository   string         `json:"repository"`
+	Fields       map[string]any `json:"fields,omitempty"`
+	Error        string         `json:"error,omitempty"`
+}
+
+func toJSONRecord(record AuditRecord) jsonRecord {
+	out := jsonRecord{Organization: record.Organization, Repository: record.Repository}
+	if record.Err != nil {
+		out.Error = record.Err.Error()
+		return out
+	}
+	out.Fields = record.Fields
+	return out
+}
+
+// csvReporter writes one CSV row per record, skipping repos that errored -
+// the caller is expected to log those separately.
+type csvReporter struct {
+	file   *os.File
+	writer *csv.Writer
+}
+
+func newCSVReporter(path string, header []string) (Reporter, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	writer := csv.NewWriter(file)
+	if err := writer.Write(header); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to write CSV header: %w", err)
+	}
+	return &csvReporter{file: file, writer: writer}, nil
+}
+
+func (r *csvReporter) WriteRecord(record AuditRecord) error {
+	if record.Err != nil {
+		return nil
+	}
+	return r.writer.Write(record.Values)
+}
+
+func (r *csvReporter) Close() error {
+	r.writer.Flush()
+	defer r.file.Close()
+	return r.writer.Error()
+}
+
+// jsonReporter buffers every record and writes them as a single JSON array
+// on Close, since a JSON array can't be closed off until the last element
+// is known.
+type jsonReporter struct {
+	file    *os.File
+	records []jsonRecord
+}
+
+func newJSONReporter(path string) (Reporter, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	return &jsonReporter{file: file}, nil
+}
+
+func (r *jsonReporter) WriteRecord(record AuditRecord) error {
+	r.records = append(r.records, toJSONRecord(record))
+	return nil
+}
+
+func (r *jsonReporter) Close() error {
+	defer r.file.Close()
+	enc := json.NewEncoder(r.file)
+	enc.SetIndent("", "  ")
+	if r.records == nil {
+		r.records = []jsonRecord{}
+	}
+	return enc.Encode(r.records)
+}
+
+// ndjsonReporter writes and flushes one JSON object per line as each
+// record arrives, so a long-running audit can be tailed with `jq` or
+// shipped into a log pipeline while it's still in progress.
+type ndjsonReporter struct {
+	file *os.File
+	enc  *json.Encoder
+}
+
+func newNDJSONReporter(path string) (Reporter, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	return &ndjsonReporter{file: file, enc: json.NewEncoder(file)}, nil
+}
+
+func (r *ndjsonReporter) WriteRecord(record AuditRecord) error {
+	if err := r.enc.Encode(toJSONRecord(record)); err != nil {
+		return err
+	}
+	return r.file.Sync()
+}
+
+func (r *ndjsonReporter) Close() error {
+	return r.file.Close()
+}
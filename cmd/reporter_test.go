@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCSVReporterWritesHeaderAndRows(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "out.csv")
+	reporter, err := NewReporter(OutputFormatCSV, path, []string{"Organization", "Repository"})
+	if err != nil {
+		t.Fatalf("NewReporter: %v", err)
+	}
+
+	if err := reporter.WriteRecord(AuditRecord{Values: []string{"acme", "widgets"}}); err != nil {
+		t.Fatalf("WriteRecord: %v", err)
+	}
+	if err := reporter.WriteRecord(AuditRecord{Organization: "acme", Repository: "broken", Err: os.ErrInvalid}); err != nil {
+		t.Fatalf("WriteRecord (errored result): %v", err)
+	}
+	if err := reporter.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer f.Close()
+
+	rows, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		t.Fatalf("reading CSV: %v", err)
+	}
+	want := [][]string{
+		{"Organization", "Repository"},
+		{"acme", "widgets"},
+	}
+	if len(rows) != len(want) {
+		t.Fatalf("rows = %v, want %v (errored results should be skipped)", rows, want)
+	}
+	for i := range want {
+		if rows[i][0] != want[i][0] || rows[i][1] != want[i][1] {
+			t.Errorf("row %d = %v, want %v", i, rows[i], want[i])
+		}
+	}
+}
+
+func TestJSONReporterWritesStructuredFields(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "out.json")
+	reporter, err := NewReporter(OutputFormatJSON, path, nil)
+	if err != nil {
+		t.Fatalf("NewReporter: %v", err)
+	}
+
+	record := AuditRecord{
+		Organization: "acme",
+		Repository:   "widgets",
+		Fields:       map[string]any{"openAlerts": float64(3), "enabled": true},
+	}
+	if err := reporter.WriteRecord(record); err != nil {
+		t.Fatalf("WriteRecord: %v", err)
+	}
+	if err := reporter.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	var got []jsonRecord
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("got %d records, want 1", len(got))
+	}
+	if got[0].Fields["openAlerts"] != float64(3) {
+		t.Errorf("Fields[openAlerts] = %v, want 3 (a number, not a string)", got[0].Fields["openAlerts"])
+	}
+	if got[0].Fields["enabled"] != true {
+		t.Errorf("Fields[enabled] = %v, want true (a bool, not a string)", got[0].Fields["enabled"])
+	}
+}
@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+)
+
+const (
+	// maxRetries caps how many attempts a single request gets, counting the first.
+	maxRetries = 5
+	// maxAbuseRetryWait caps how long we honor an AbuseRateLimitError's RetryAfter.
+	maxAbuseRetryWait = 2 * time.Minute
+	// baseBackoff is the starting delay for exponential backoff on 5xx errors.
+	baseBackoff = 1 * time.Second
+)
+
+// withRetry waits for the client's throttler to admit the request, then
+// calls op, which should perform a single go-github SDK call and return its
+// *github.Response. It retries on rate limit and transient 5xx errors,
+// switching on the typed errors go-github returns so retry decisions don't
+// depend on guessing which headers a given response carried, and feeds
+// every observed rate limit snapshot back into the throttler so later
+// requests stay ahead of the limit instead of reacting to it.
+func (c *Client) withRetry(ctx context.Context, op func() (*github.Response, error)) error {
+	var lastErr error
+
+	for attempt := 0; attempt < maxRetries; attempt++ {
+		if attempt > 0 {
+			fmt.Printf("Retry attempt %d/%d for request...\n", attempt, maxRetries)
+		}
+
+		release, err := c.throttler.Wait(ctx)
+		if err != nil {
+			return err
+		}
+		resp, err := op()
+		release()
+
+		if resp != nil {
+			c.throttler.Observe(resp.Rate)
+		}
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		switch e := err.(type) {
+		case *github.RateLimitError:
+			c.throttler.Observe(e.Rate)
+			wait := time.Until(e.Rate.Reset.Time) + rateLimitResetBuffer
+			fmt.Printf("Primary rate limit exceeded, resets at %s, waiting %.0f seconds...\n",
+				e.Rate.Reset.Time.Format("15:04:05"), wait.Seconds())
+			if wait > 0 {
+				sleepOrCancel(ctx, wait)
+			}
+			continue
+		case *github.AbuseRateLimitError:
+			wait := defaultRateLimitWaitSeconds * time.Second
+			if e.RetryAfter != nil {
+				wait = *e.RetryAfter
+			}
+			if wait > maxAbuseRetryWait {
+				wait = maxAbuseRetryWait
+			}
+			fmt.Printf("Secondary rate limit (abuse detection) exceeded, waiting %.0f seconds...\n", wait.Seconds())
+			c.throttler.Penalize(wait)
+			sleepOrCancel(ctx, wait)
+			continue
+		default:
+			if resp != nil && resp.StatusCode >= 500 {
+				wait := backoffWithJitter(attempt)
+				fmt.Printf("Server error %d, backing off %.0f seconds...\n", resp.StatusCode, wait.Seconds())
+				sleepOrCancel(ctx, wait)
+				continue
+			}
+			if wait, ok := headerRateLimitWait(resp); ok {
+				fmt.Printf("Rate limit detected from response headers, waiting %.0f seconds...\n", wait.Seconds())
+				c.throttler.Penalize(wait)
+				sleepOrCancel(ctx, wait)
+				continue
+			}
+			return fmt.Errorf("request failed: %w", err)
+		}
+	}
+
+	return fmt.Errorf("exceeded maximum retries: %w", lastErr)
+}
+
+// backoffWithJitter returns an exponential backoff duration with jitter for the given attempt.
+func backoffWithJitter(attempt int) time.Duration {
+	backoff := baseBackoff * time.Duration(int64(1)<<uint(attempt))
+	jitter := time.Duration(rand.Int63n(int64(baseBackoff)))
+	return backoff + jitter
+}
+
+// headerRateLimitWait falls back to the raw X-RateLimit-Reset/Retry-After
+// headers when the SDK surfaces an error type it didn't classify as a rate
+// limit (e.g. a proxy that rewrote the status code but kept the headers).
+func headerRateLimitWait(resp *github.Response) (time.Duration, bool) {
+	if resp == nil || resp.Response == nil {
+		return 0, false
+	}
+	if retryAfter := resp.Response.Header.Get("Retry-After"); retryAfter != "" {
+		if seconds, err := strconv.Atoi(retryAfter); err == nil {
+			return time.Duration(seconds) * time.Second, true
+		}
+	}
+	if resp.Rate.Limit > 0 && resp.Rate.Remaining == 0 && !resp.Rate.Reset.IsZero() {
+		return time.Until(resp.Rate.Reset.Time) + rateLimitResetBuffer, true
+	}
+	return 0, false
+}
+
+// sleepOrCancel sleeps for d, returning early if ctx is cancelled.
+func sleepOrCancel(ctx context.Context, d time.Duration) {
+	if d <= 0 {
+		return
+	}
+	_ = sleepOrCancelErr(ctx, d)
+}
@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+)
+
+func TestBackoffWithJitterGrowsExponentially(t *testing.T) {
+	for attempt := 0; attempt < 4; attempt++ {
+		min := baseBackoff << uint(attempt)
+		max := min + baseBackoff
+		wait := backoffWithJitter(attempt)
+		if wait < min || wait >= max {
+			t.Errorf("backoffWithJitter(%d) = %v, want in [%v, %v)", attempt, wait, min, max)
+		}
+	}
+}
+
+func TestHeaderRateLimitWaitPrefersRetryAfter(t *testing.T) {
+	resp := &github.Response{
+		Response: &http.Response{Header: http.Header{"Retry-After": {"5"}}},
+		Rate:     github.Rate{Limit: 5000, Remaining: 0, Reset: github.Timestamp{Time: time.Now().Add(time.Hour)}},
+	}
+	wait, ok := headerRateLimitWait(resp)
+	if !ok || wait != 5*time.Second {
+		t.Errorf("headerRateLimitWait = (%v, %v), want (5s, true)", wait, ok)
+	}
+}
+
+func TestHeaderRateLimitWaitFallsBackToRateHeaders(t *testing.T) {
+	reset := time.Now().Add(10 * time.Second)
+	resp := &github.Response{
+		Response: &http.Response{Header: http.Header{}},
+		Rate:     github.Rate{Limit: 5000, Remaining: 0, Reset: github.Timestamp{Time: reset}},
+	}
+	wait, ok := headerRateLimitWait(resp)
+	if !ok || wait <= 0 {
+		t.Errorf("headerRateLimitWait = (%v, %v), want a positive wait", wait, ok)
+	}
+}
+
+func TestHeaderRateLimitWaitNoSignal(t *testing.T) {
+	resp := &github.Response{Response: &http.Response{Header: http.Header{}}}
+	if _, ok := headerRateLimitWait(resp); ok {
+		t.Error("headerRateLimitWait with no Retry-After/rate headers, want ok = false")
+	}
+	if _, ok := headerRateLimitWait(nil); ok {
+		t.Error("headerRateLimitWait(nil), want ok = false")
+	}
+}
+
+func testRetryClient() *Client {
+	return &Client{throttler: NewThrottler(1000, 10)}
+}
+
+func TestWithRetrySucceedsWithoutRetrying(t *testing.T) {
+	client := testRetryClient()
+	calls := 0
+	err := client.withRetry(context.Background(), func() (*github.Response, error) {
+		calls++
+		return &github.Response{}, nil
+	})
+	if err != nil {
+		t.Fatalf("withRetry: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("op called %d times, want 1", calls)
+	}
+}
+
+func TestWithRetryRetriesOnRateLimitError(t *testing.T) {
+	client := testRetryClient()
+	calls := 0
+	err := client.withRetry(context.Background(), func() (*github.Response, error) {
+		calls++
+		if calls == 1 {
+			return nil, &github.RateLimitError{
+				Rate: github.Rate{Reset: github.Timestamp{Time: time.Now().Add(-10 * time.Second)}},
+			}
+		}
+		return &github.Response{}, nil
+	})
+	if err != nil {
+		t.Fatalf("withRetry: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("op called %d times, want 2 (one failure, one success)", calls)
+	}
+}
+
+func TestWithRetryRetriesOnAbuseRateLimitError(t *testing.T) {
+	client := testRetryClient()
+	calls := 0
+	retryAfter := time.Millisecond
+	err := client.withRetry(context.Background(), func() (*github.Response, error) {
+		calls++
+		if calls == 1 {
+			return nil, &github.AbuseRateLimitError{RetryAfter: &retryAfter}
+		}
+		return &github.Response{}, nil
+	})
+	if err != nil {
+		t.Fatalf("withRetry: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("op called %d times, want 2 (one failure, one success)", calls)
+	}
+}
+
+func TestWithRetryGivesUpOnNonRetryableError(t *testing.T) {
+	client := testRetryClient()
+	wantErr := errors.New("boom")
+	calls := 0
+	err := client.withRetry(context.Background(), func() (*github.Response, error) {
+		calls++
+		return &github.Response{Response: &http.Response{StatusCode: http.StatusBadRequest}}, wantErr
+	})
+	if err == nil || !errors.Is(err, wantErr) {
+		t.Fatalf("withRetry = %v, want an error wrapping %v", err, wantErr)
+	}
+	if calls != 1 {
+		t.Errorf("op called %d times, want 1 (non-retryable errors shouldn't retry)", calls)
+	}
+}
+
+func TestWithRetryStopsWhenContextIsCancelled(t *testing.T) {
+	client := testRetryClient()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := client.withRetry(ctx, func() (*github.Response, error) {
+		return nil, context.Canceled
+	})
+	if err == nil {
+		t.Fatal("withRetry with a cancelled context, want an error")
+	}
+}
@@ -1,19 +1,31 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
 
 	"github.com/spf13/cobra"
 )
 
 // Holds flags for organizations and repository.
 var (
-	Organizations string
-	Repository    string
-	CSVOutput     string // File path for CSV output
-	SkipArchived  bool   // Skip archived repositories
-	SkipForks     bool   // Skip forked repositories
+	Organizations  string
+	Repository     string
+	CSVOutput      string  // File path for CSV output
+	SkipArchived   bool    // Skip archived repositories
+	SkipForks      bool    // Skip forked repositories
+	MaxRPS         float64 // Maximum requests per second sent to the GitHub API
+	MaxConcurrency int     // Maximum number of in-flight requests to the GitHub API
+	Concurrency    int     // Number of repositories audited in parallel
+	OutputFormat   string  // Report output format: csv, json or ndjson
+
+	AppID             int64  // GitHub App ID for installation authentication
+	AppPrivateKey     string // Path to the GitHub App private key (PEM)
+	AppInstallationID int64  // GitHub App installation ID to authenticate as
 )
 
 // rootCmd is the base command called without any subcommands.
@@ -59,14 +71,71 @@ func init() {
 		false,
 		"Skip forked repositories",
 	)
+	rootCmd.PersistentFlags().Float64Var(
+		&MaxRPS,
+		"max-rps",
+		defaultMaxRPS,
+		"Maximum number of requests per second sent to the GitHub API",
+	)
+	rootCmd.PersistentFlags().IntVar(
+		&MaxConcurrency,
+		"max-concurrency",
+		defaultMaxConcurrency,
+		"Maximum number of concurrent requests to the GitHub API",
+	)
+	rootCmd.PersistentFlags().IntVar(
+		&Concurrency,
+		"concurrency",
+		defaultConcurrency,
+		"Number of repositories to audit in parallel",
+	)
+	rootCmd.PersistentFlags().StringVar(
+		&OutputFormat,
+		"output-format",
+		OutputFormatCSV,
+		"Report output format: csv, json or ndjson",
+	)
+	rootCmd.PersistentFlags().Int64Var(
+		&AppID,
+		"app-id",
+		envInt64("GH_GHAS_AUDIT_APP_ID"),
+		"GitHub App ID to authenticate as (env: GH_GHAS_AUDIT_APP_ID)",
+	)
+	rootCmd.PersistentFlags().StringVar(
+		&AppPrivateKey,
+		"app-private-key",
+		os.Getenv("GH_GHAS_AUDIT_APP_PRIVATE_KEY"),
+		"Path to the GitHub App private key, PEM encoded (env: GH_GHAS_AUDIT_APP_PRIVATE_KEY)",
+	)
+	rootCmd.PersistentFlags().Int64Var(
+		&AppInstallationID,
+		"app-installation-id",
+		envInt64("GH_GHAS_AUDIT_APP_INSTALLATION_ID"),
+		"GitHub App installation ID to authenticate as (env: GH_GHAS_AUDIT_APP_INSTALLATION_ID)",
+	)
 
 	// Attach code-scanning subcommand.
 	rootCmd.AddCommand(codeScanningAuditCmd)
 }
 
-// Execute runs the main CLI command.
+// envInt64 parses the named environment variable as an int64, returning 0
+// if it's unset or malformed.
+func envInt64(name string) int64 {
+	value, err := strconv.ParseInt(os.Getenv(name), 10, 64)
+	if err != nil {
+		return 0
+	}
+	return value
+}
+
+// Execute runs the main CLI command. The command tree runs under a context
+// that's cancelled on Ctrl-C or SIGTERM, so in-flight HTTP requests and
+// worker pools actually stop instead of running to completion.
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := rootCmd.ExecuteContext(ctx); err != nil {
 		fmt.Println(err)
 		os.Exit(1)
 	}
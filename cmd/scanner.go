@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"context"
+	"sync"
+)
+
+const (
+	// defaultConcurrency is how many repos are audited in parallel when
+	// --concurrency isn't set.
+	defaultConcurrency = 4
+)
+
+// RepoResult pairs a single repo's audit outcome with any error encountered,
+// so a failed repo doesn't abort the rest of the audit.
+type RepoResult struct {
+	Org    string
+	Repo   string
+	Row    []string
+	Fields map[string]any
+	Err    error
+}
+
+// RepoAuditFunc audits a single repository and returns its CSV row
+// alongside the same data as a structured payload for JSON output.
+type RepoAuditFunc func(ctx context.Context, client *Client, org string, repo string) ([]string, map[string]any, error)
+
+// Scanner fans repo-level audit work out over a bounded pool of goroutines,
+// coordinating with the client's throttler so concurrency doesn't outpace
+// the rate limit budget.
+type Scanner struct {
+	client      *Client
+	concurrency int
+}
+
+// NewScanner builds a Scanner that audits at most concurrency repos at
+// once. A non-positive concurrency falls back to defaultConcurrency.
+func NewScanner(client *Client, concurrency int) *Scanner {
+	if concurrency <= 0 {
+		concurrency = defaultConcurrency
+	}
+	return &Scanner{client: client, concurrency: concurrency}
+}
+
+// Scan runs fn for every repo in repos, audited at most s.concurrency at a
+// time, and streams each outcome onto the returned channel as it completes.
+// The channel is closed once every repo has been processed or ctx is
+// cancelled.
+func (s *Scanner) Scan(ctx context.Context, org string, repos []string, fn RepoAuditFunc) <-chan RepoResult {
+	jobs := make(chan string)
+	results := make(chan RepoResult)
+
+	go func() {
+		defer close(jobs)
+		for _, repo := range repos {
+			select {
+			case jobs <- repo:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for i := 0; i < s.concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for repo := range jobs {
+				row, fields, err := fn(ctx, s.client, org, repo)
+				select {
+				case results <- RepoResult{Org: org, Repo: repo, Row: row, Fields: fields, Err: err}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	return results
+}
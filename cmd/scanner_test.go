@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestScannerScanAuditsEveryRepo(t *testing.T) {
+	repos := []string{"a", "b", "c", "d"}
+	scanner := NewScanner(nil, 2)
+
+	var calls int32
+	results := scanner.Scan(context.Background(), "org", repos, func(ctx context.Context, client *Client, org string, repo string) ([]string, map[string]any, error) {
+		atomic.AddInt32(&calls, 1)
+		return []string{org, repo}, nil, nil
+	})
+
+	seen := make(map[string]bool)
+	for result := range results {
+		if result.Err != nil {
+			t.Fatalf("unexpected error for %s/%s: %v", result.Org, result.Repo, result.Err)
+		}
+		seen[result.Repo] = true
+	}
+
+	if int(calls) != len(repos) {
+		t.Fatalf("fn called %d times, want %d", calls, len(repos))
+	}
+	for _, repo := range repos {
+		if !seen[repo] {
+			t.Errorf("missing result for repo %q", repo)
+		}
+	}
+}
+
+func TestScannerScanStopsOnCancellation(t *testing.T) {
+	repos := make([]string, 100)
+	for i := range repos {
+		repos[i] = "repo"
+	}
+	scanner := NewScanner(nil, 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	results := scanner.Scan(ctx, "org", repos, func(ctx context.Context, client *Client, org string, repo string) ([]string, map[string]any, error) {
+		cancel()
+		return nil, nil, nil
+	})
+
+	done := make(chan struct{})
+	go func() {
+		for range results {
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Scan did not stop after context cancellation")
+	}
+}
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/google/go-github/v57/github"
+)
+
+// secretScanningAuditor reports a repository's secret-scanning and
+// push-protection enablement alongside its open and resolved alert counts.
+type secretScanningAuditor struct{}
+
+// Name identifies this auditor for the `all` subcommand's output file.
+func (secretScanningAuditor) Name() string { return "secret-scanning" }
+
+// CSVHeader returns the column names for this auditor's CSV output.
+func (secretScanningAuditor) CSVHeader() []string {
+	return []string{"Organization", "Repository", "Enabled", "PushProtection", "OpenAlerts", "ResolvedAlerts"}
+}
+
+// Audit inspects a single repository's secret-scanning configuration and alerts.
+func (secretScanningAuditor) Audit(ctx context.Context, client *Client, org string, repo string) (Row, map[string]any, error) {
+	var repository *github.Repository
+	err := client.withRetry(ctx, func() (*github.Response, error) {
+		var (
+			err  error
+			resp *github.Response
+		)
+		repository, resp, err = client.rest.Repositories.Get(ctx, org, repo)
+		return resp, err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	enabled, pushProtection := secretScanningStatus(repository)
+
+	open, err := countSecretScanningAlerts(ctx, client, org, repo, "open")
+	if err != nil {
+		return nil, nil, err
+	}
+	resolved, err := countSecretScanningAlerts(ctx, client, org, repo, "resolved")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	row := Row{
+		org,
+		repo,
+		enabled,
+		pushProtection,
+		strconv.Itoa(open),
+		strconv.Itoa(resolved),
+	}
+	fields := map[string]any{
+		"enabled":        enabled,
+		"pushProtection": pushProtection,
+		"openAlerts":     open,
+		"resolvedAlerts": resolved,
+	}
+	return row, fields, nil
+}
+
+// secretScanningStatus extracts the secret-scanning and push-protection
+// enablement state from a repository's security_and_analysis block.
+func secretScanningStatus(repo *github.Repository) (enabled string, pushProtection string) {
+	analysis := repo.GetSecurityAndAnalysis()
+	if analysis == nil {
+		return "unknown", "unknown"
+	}
+	enabled = "disabled"
+	if status := analysis.GetSecretScanning(); status != nil {
+		enabled = status.GetStatus()
+	}
+	pushProtection = "disabled"
+	if status := analysis.GetSecretScanningPushProtection(); status != nil {
+		pushProtection = status.GetStatus()
+	}
+	return enabled, pushProtection
+}
+
+// countSecretScanningAlerts counts the secret-scanning alerts for a
+// repository in the given state ("open" or "resolved").
+func countSecretScanningAlerts(ctx context.Context, client *Client, org string, repo string, state string) (int, error) {
+	opts := &github.SecretScanningAlertListOptions{
+		State:             state,
+		ListCursorOptions: github.ListCursorOptions{PerPage: 100},
+	}
+
+	count := 0
+	for {
+		var (
+			alerts []*github.SecretScanningAlert
+			resp   *github.Response
+		)
+		err := client.withRetry(ctx, func() (*github.Response, error) {
+			var err error
+			alerts, resp, err = client.rest.SecretScanning.ListAlertsForRepo(ctx, org, repo, opts)
+			return resp, err
+		})
+		if err != nil {
+			return 0, err
+		}
+		count += len(alerts)
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.ListCursorOptions.Page = strconv.Itoa(resp.NextPage)
+	}
+
+	return count, nil
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-github/v57/github"
+)
+
+func TestSecretScanningStatusNoAnalysisBlock(t *testing.T) {
+	enabled, pushProtection := secretScanningStatus(&github.Repository{})
+	if enabled != "unknown" || pushProtection != "unknown" {
+		t.Errorf("secretScanningStatus(no analysis) = (%q, %q), want (unknown, unknown)", enabled, pushProtection)
+	}
+}
+
+func TestSecretScanningStatusEnabled(t *testing.T) {
+	repo := &github.Repository{
+		SecurityAndAnalysis: &github.SecurityAndAnalysis{
+			SecretScanning:               &github.SecretScanning{Status: github.String("enabled")},
+			SecretScanningPushProtection: &github.SecretScanningPushProtection{Status: github.String("enabled")},
+		},
+	}
+	enabled, pushProtection := secretScanningStatus(repo)
+	if enabled != "enabled" || pushProtection != "enabled" {
+		t.Errorf("secretScanningStatus = (%q, %q), want (enabled, enabled)", enabled, pushProtection)
+	}
+}
+
+func TestSecretScanningStatusDefaultsToDisabled(t *testing.T) {
+	repo := &github.Repository{SecurityAndAnalysis: &github.SecurityAndAnalysis{}}
+	enabled, pushProtection := secretScanningStatus(repo)
+	if enabled != "disabled" || pushProtection != "disabled" {
+		t.Errorf("secretScanningStatus(empty analysis) = (%q, %q), want (disabled, disabled)", enabled, pushProtection)
+	}
+}
+
+func TestCountSecretScanningAlertsPaginates(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/acme/widgets/secret-scanning/alerts", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			fmt.Fprint(w, `[{"number":3}]`)
+			return
+		}
+		w.Header().Set("Link", `<http://`+r.Host+`/repos/acme/widgets/secret-scanning/alerts?page=2>; rel="next"`)
+		fmt.Fprint(w, `[{"number":1},{"number":2}]`)
+	})
+	client := newTestClient(t, mux)
+
+	count, err := countSecretScanningAlerts(context.Background(), client, "acme", "widgets", "open")
+	if err != nil {
+		t.Fatalf("countSecretScanningAlerts: %v", err)
+	}
+	if count != 3 {
+		t.Errorf("countSecretScanningAlerts = %d, want 3", count)
+	}
+}
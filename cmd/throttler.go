@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+)
+
+const (
+	// defaultMaxRPS is the default short-window request budget, chosen to stay
+	// comfortably clear of GitHub's secondary (abuse-detection) rate limit.
+	defaultMaxRPS = 80.0 / 60.0
+	// defaultMaxConcurrency caps how many requests are in flight at once.
+	defaultMaxConcurrency = 4
+)
+
+// Throttler proactively paces outgoing requests so an audit of a large org
+// doesn't trip GitHub's primary or secondary rate limits in the first place.
+// It enforces two budgets: a primary token bucket derived from the most
+// recently observed X-RateLimit-* headers, and a short-window limiter that
+// bounds requests-per-second and in-flight concurrency.
+type Throttler struct {
+	mu sync.Mutex
+
+	// primary budget, refilled from the most recently observed rate limit response.
+	primaryLimit     int
+	primaryRemaining int
+	primaryReset     time.Time
+
+	// short-window budget guarding against secondary (abuse-detection) limits.
+	rps          float64
+	windowTokens float64
+	lastRefill   time.Time
+	penalizedAt  time.Time
+
+	sem chan struct{}
+}
+
+// NewThrottler builds a Throttler allowing up to maxRPS requests per second
+// with at most maxConcurrency requests in flight. Non-positive values fall
+// back to the package defaults.
+func NewThrottler(maxRPS float64, maxConcurrency int) *Throttler {
+	if maxRPS <= 0 {
+		maxRPS = defaultMaxRPS
+	}
+	if maxConcurrency <= 0 {
+		maxConcurrency = defaultMaxConcurrency
+	}
+	return &Throttler{
+		rps:          maxRPS,
+		windowTokens: maxRPS,
+		lastRefill:   time.Now(),
+		sem:          make(chan struct{}, maxConcurrency),
+	}
+}
+
+// Wait blocks until a request is allowed to proceed, respecting both the
+// concurrency cap and the short-window and primary rate budgets. The
+// returned release func must be called once the request completes.
+func (t *Throttler) Wait(ctx context.Context) (release func(), err error) {
+	select {
+	case t.sem <- struct{}{}:
+	case <-ctx.Done():
+		return func() {}, ctx.Err()
+	}
+	release = func() { <-t.sem }
+
+	for {
+		wait := t.reserve()
+		if wait <= 0 {
+			return release, nil
+		}
+		if err := sleepOrCancelErr(ctx, wait); err != nil {
+			release()
+			return func() {}, err
+		}
+	}
+}
+
+// reserve checks the window and primary budgets, consuming a window token
+// and returning the duration the caller should wait before trying again.
+func (t *Throttler) reserve() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now()
+	t.refillWindow(now)
+
+	if now.Before(t.penalizedAt) {
+		return t.penalizedAt.Sub(now)
+	}
+
+	if t.primaryLimit > 0 && t.primaryRemaining <= 0 && now.Before(t.primaryReset) {
+		return t.primaryReset.Sub(now) + rateLimitResetBuffer
+	}
+
+	if t.windowTokens < 1 {
+		return time.Duration(float64(time.Second) / t.rps)
+	}
+
+	t.windowTokens--
+	return 0
+}
+
+// refillWindow tops up the short-window token bucket based on elapsed time.
+func (t *Throttler) refillWindow(now time.Time) {
+	elapsed := now.Sub(t.lastRefill).Seconds()
+	if elapsed <= 0 {
+		return
+	}
+	t.windowTokens += elapsed * t.rps
+	if t.windowTokens > t.rps {
+		t.windowTokens = t.rps
+	}
+	t.lastRefill = now
+}
+
+// Observe updates the primary budget from a response's rate limit snapshot.
+func (t *Throttler) Observe(rate github.Rate) {
+	if rate.Limit == 0 {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.primaryLimit = rate.Limit
+	t.primaryRemaining = rate.Remaining
+	t.primaryReset = rate.Reset.Time
+}
+
+// Penalize drains the short-window budget for d, forcing subsequent
+// requests to pause. It's called when a 429/secondary-limit response comes
+// back despite the proactive budgets, so the throttler self-corrects.
+func (t *Throttler) Penalize(d time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	until := time.Now().Add(d)
+	if until.After(t.penalizedAt) {
+		t.penalizedAt = until
+	}
+	t.windowTokens = 0
+}
+
+// sleepOrCancelErr sleeps for d, returning ctx.Err() if ctx is cancelled first.
+func sleepOrCancelErr(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
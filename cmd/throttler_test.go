@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestThrottlerRefillWindow(t *testing.T) {
+	th := &Throttler{rps: 2, windowTokens: 0, lastRefill: time.Unix(0, 0)}
+
+	th.refillWindow(time.Unix(0, 0).Add(500 * time.Millisecond))
+	if got, want := th.windowTokens, 1.0; got != want {
+		t.Errorf("windowTokens after 500ms = %v, want %v", got, want)
+	}
+
+	// Refilling shouldn't overshoot the rps cap.
+	th.refillWindow(time.Unix(0, 0).Add(10 * time.Second))
+	if got, want := th.windowTokens, 2.0; got != want {
+		t.Errorf("windowTokens after long gap = %v, want cap %v", got, want)
+	}
+}
+
+func TestThrottlerReserveConsumesWindowToken(t *testing.T) {
+	th := &Throttler{rps: 2, windowTokens: 1, lastRefill: time.Now()}
+
+	if wait := th.reserve(); wait != 0 {
+		t.Fatalf("reserve() with a token available = %v, want 0", wait)
+	}
+	if th.windowTokens >= 1 {
+		t.Fatalf("reserve() didn't consume a window token, windowTokens = %v", th.windowTokens)
+	}
+}
+
+func TestThrottlerReserveWaitsOnExhaustedWindow(t *testing.T) {
+	th := &Throttler{rps: 2, windowTokens: 0, lastRefill: time.Now()}
+
+	if wait := th.reserve(); wait <= 0 {
+		t.Fatalf("reserve() with no tokens = %v, want a positive wait", wait)
+	}
+}
+
+func TestThrottlerReserveHonorsPrimaryLimit(t *testing.T) {
+	th := &Throttler{
+		rps:              100,
+		windowTokens:     100,
+		lastRefill:       time.Now(),
+		primaryLimit:     1,
+		primaryRemaining: 0,
+		primaryReset:     time.Now().Add(time.Minute),
+	}
+
+	wait := th.reserve()
+	if wait <= 0 {
+		t.Fatalf("reserve() with exhausted primary budget = %v, want a positive wait", wait)
+	}
+}
+
+func TestThrottlerReserveHonorsPenalty(t *testing.T) {
+	th := &Throttler{rps: 100, windowTokens: 100, lastRefill: time.Now()}
+	th.Penalize(50 * time.Millisecond)
+
+	if th.windowTokens != 0 {
+		t.Fatalf("Penalize didn't drain the window budget, windowTokens = %v", th.windowTokens)
+	}
+	if wait := th.reserve(); wait <= 0 {
+		t.Fatalf("reserve() while penalized = %v, want a positive wait", wait)
+	}
+}
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-github/v57/github"
+)
+
+// newTestClient points a Client at a local httptest.Server standing in for
+// the GitHub API, so ListOrgs/ListRepos/etc. can be tested without a real
+// token or network access.
+func newTestClient(t *testing.T, mux *http.ServeMux) *Client {
+	t.Helper()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	baseURL, err := url.Parse(server.URL + "/")
+	if err != nil {
+		t.Fatalf("url.Parse: %v", err)
+	}
+
+	rest := github.NewClient(server.Client())
+	rest.BaseURL = baseURL
+	return &Client{rest: rest, throttler: NewThrottler(1000, 10)}
+}
+
+func TestListOrgsPaginatesViaNextPage(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/user/orgs", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			fmt.Fprint(w, `[{"login":"org-b"}]`)
+			return
+		}
+		w.Header().Set("Link", `<http://`+r.Host+`/user/orgs?page=2>; rel="next"`)
+		fmt.Fprint(w, `[{"login":"org-a"}]`)
+	})
+	client := newTestClient(t, mux)
+
+	orgs, err := ListOrgs(context.Background(), client)
+	if err != nil {
+		t.Fatalf("ListOrgs: %v", err)
+	}
+	want := []string{"org-a", "org-b"}
+	if len(orgs) != len(want) || orgs[0] != want[0] || orgs[1] != want[1] {
+		t.Errorf("ListOrgs = %v, want %v", orgs, want)
+	}
+}
+
+func TestListReposFiltersArchivedAndForks(t *testing.T) {
+	origArchived, origForks := SkipArchived, SkipForks
+	defer func() { SkipArchived, SkipForks = origArchived, origForks }()
+	SkipArchived, SkipForks = true, true
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/orgs/acme/repos", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[
+			{"name":"kept"},
+			{"name":"archived","archived":true},
+			{"name":"forked","fork":true}
+		]`)
+	})
+	client := newTestClient(t, mux)
+
+	repos, err := ListRepos(context.Background(), client, "acme")
+	if err != nil {
+		t.Fatalf("ListRepos: %v", err)
+	}
+	if len(repos) != 1 || repos[0] != "kept" {
+		t.Errorf("ListRepos = %v, want [kept]", repos)
+	}
+}
+
+func TestGetDefaultSetupParsesResponse(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/acme/widgets/code-scanning/default-setup", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"state":"configured","languages":["python","go"],"query_suite":"extended"}`)
+	})
+	client := newTestClient(t, mux)
+
+	setup, err := GetDefaultSetup(context.Background(), client, "acme", "widgets")
+	if err != nil {
+		t.Fatalf("GetDefaultSetup: %v", err)
+	}
+	if setup.State != "configured" || setup.QuerySuite != "extended" {
+		t.Errorf("GetDefaultSetup = %+v, want State=configured QuerySuite=extended", setup)
+	}
+	if len(setup.Languages) != 2 || setup.Languages[0] != "python" {
+		t.Errorf("GetDefaultSetup.Languages = %v, want [python go]", setup.Languages)
+	}
+}
+
+func TestGetLanguagesReturnsCoverage(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/acme/widgets/languages", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"Python":1000,"Go":500}`)
+	})
+	client := newTestClient(t, mux)
+
+	langs, err := GetLanguages(context.Background(), client, "acme", "widgets")
+	if err != nil {
+		t.Fatalf("GetLanguages: %v", err)
+	}
+	if langs["Python"] != 1000 || langs["Go"] != 500 {
+		t.Errorf("GetLanguages = %v, want Python=1000 Go=500", langs)
+	}
+}